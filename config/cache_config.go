@@ -12,6 +12,16 @@ type CacheConfig struct {
 	StringConnection  string
 	AppPort           string
 	UserTimeToRefresh int
+	DBDriver          string
+	JWTSecret         string
+	JWTAccessTTL      int
+	JWTRefreshTTL     int
+	JWTIssuer         string
+	LogDir            string
+	LogMaxSizeMB      int
+	LogMaxBackups     int
+	LogLevel          string
+	LogFormat         string
 }
 
 var (
@@ -35,5 +45,15 @@ func (c CacheConfig) GetFieldMapper() map[string]string {
 		"STRING_CONNECTION":    "StringConnection",
 		"APP_PORT":             "AppPort",
 		"USER_TIME_TO_REFRESH": "UserTimeToRefresh",
+		"DB_DRIVER":            "DBDriver",
+		"JWT_SECRET":           "JWTSecret",
+		"JWT_ACCESS_TTL":       "JWTAccessTTL",
+		"JWT_REFRESH_TTL":      "JWTRefreshTTL",
+		"JWT_ISSUER":           "JWTIssuer",
+		"LOG_DIR":              "LogDir",
+		"LOG_MAX_SIZE_MB":      "LogMaxSizeMB",
+		"LOG_MAX_BACKUPS":      "LogMaxBackups",
+		"LOG_LEVEL":            "LogLevel",
+		"LOG_FORMAT":           "LogFormat",
 	}
 }
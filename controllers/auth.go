@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/IacopoMelani/Go-Starter-Project/config"
+	"github.com/IacopoMelani/Go-Starter-Project/models/table"
+	record "github.com/IacopoMelani/Go-Starter-Project/pkg/models/table_record"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/labstack/echo"
+)
+
+// refreshRequest - Corpo atteso da /user/refresh e /user/logout
+type refreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// hashToken - Restituisce l'hash SHA-256, in esadecimale, del token opaco passato; solo l'hash viene persistito
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// mintAccessToken - Genera un nuovo JWT di accesso per l'utente passato, firmato e con scadenza/issuer da config
+func mintAccessToken(userID int64) (string, error) {
+
+	conf := config.GetInstance()
+
+	claims := &JwtCustomClaims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    conf.JWTIssuer,
+			ExpiresAt: time.Now().Add(time.Duration(conf.JWTAccessTTL) * time.Second).Unix(),
+		},
+		UserID: userID,
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(conf.JWTSecret))
+}
+
+// IssueRefreshToken - Genera un nuovo refresh token opaco per l'utente passato, lo salva (come hash) con la
+// scadenza configurata e lo restituisce in chiaro, da consegnare una sola volta al client
+func IssueRefreshToken(ctx context.Context, userID int64) (string, error) {
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+
+	token := hex.EncodeToString(raw)
+
+	rt := table.NewRefreshToken()
+	rt.TokenHash = hashToken(token)
+	rt.UserID = userID
+	rt.ExpiresAt = time.Now().Add(time.Duration(config.GetInstance().JWTRefreshTTL) * time.Second)
+
+	if err := record.SaveCtx(ctx, rt); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RefreshToken - Valida il refresh token passato e, se ancora valido, lo revoca e ne emette uno nuovo
+// insieme a un nuovo JWT di accesso
+func RefreshToken(c echo.Context) error {
+
+	req := new(refreshRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	rt, err := table.LoadRefreshTokenByHash(hashToken(req.RefreshToken))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	if rt == nil || rt.Revoked || rt.IsExpired(time.Now()) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"error": "refresh token non valido"})
+	}
+
+	rt.Revoked = true
+	if err := record.SaveCtx(c.Request().Context(), rt); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	accessToken, err := mintAccessToken(rt.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	newRefreshToken, err := IssueRefreshToken(c.Request().Context(), rt.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, echo.Map{
+		"access_token":  accessToken,
+		"refresh_token": newRefreshToken,
+	})
+}
+
+// Logout - Revoca il refresh token passato, impedendone un successivo utilizzo
+func Logout(c echo.Context) error {
+
+	req := new(refreshRequest)
+	if err := c.Bind(req); err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"error": err.Error()})
+	}
+
+	rt, err := table.LoadRefreshTokenByHash(hashToken(req.RefreshToken))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	if rt == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	rt.Revoked = true
+
+	if err := record.SaveCtx(c.Request().Context(), rt); err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
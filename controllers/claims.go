@@ -0,0 +1,29 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/IacopoMelani/Go-Starter-Project/config"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// JwtCustomClaims - Claims custom del JWT di accesso, usate dal middleware JWT configurato in routes
+type JwtCustomClaims struct {
+	jwt.StandardClaims
+	UserID int64 `json:"user_id"`
+}
+
+// Valid - Oltre alla validazione standard (exp/nbf/iat) rifiuta i token il cui issuer non corrisponde a quello configurato
+func (c JwtCustomClaims) Valid() error {
+
+	if err := c.StandardClaims.Valid(); err != nil {
+		return err
+	}
+
+	if c.Issuer != config.GetInstance().JWTIssuer {
+		return errors.New("issuer del token non valido")
+	}
+
+	return nil
+}
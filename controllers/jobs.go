@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/jobs"
+
+	"github.com/labstack/echo"
+)
+
+// ListJobs - Restituisce l'elenco dei job registrati nello scheduler, con i relativi orari di esecuzione
+func ListJobs(c echo.Context) error {
+	return c.JSON(http.StatusOK, jobs.List())
+}
+
+// TriggerJob - Esegue immediatamente il job identificato dal parametro di route "id", rispettando il lock distribuito
+func TriggerJob(c echo.Context) error {
+
+	id := c.Param("id")
+
+	if err := jobs.Trigger(c.Request().Context(), id); err != nil {
+
+		if errors.Is(err, jobs.ErrJobNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"error": err.Error()})
+		}
+
+		return c.JSON(http.StatusInternalServerError, echo.Map{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/IacopoMelani/Go-Starter-Project/config"
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db/migrate"
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/jobs"
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/log"
+	"github.com/IacopoMelani/Go-Starter-Project/routes"
+
+	"github.com/labstack/echo"
+)
+
+func main() {
+
+	conf := config.GetInstance()
+
+	log.InitFromConfig(conf.AppName, conf)
+
+	dialect := db.Dialect(conf.DBDriver)
+	if dialect == "" {
+		dialect = db.DialectMySQL
+	}
+
+	if err := db.Init(dialect, conf.StringConnection); err != nil {
+		panic(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(os.Args[2:])
+		return
+	}
+
+	jobs.Start()
+	defer jobs.Stop()
+
+	e := echo.New()
+	e.Use(routes.RequestLogger())
+
+	routes.InitGetRoutes(e)
+	routes.InitPostRoutes(e)
+
+	e.Logger.Fatal(e.Start(":" + conf.AppPort))
+}
+
+// runMigrateCommand - Gestisce il sottocomando "migrate" (up, down, status)
+func runMigrateCommand(args []string) {
+
+	sdb := db.GetDB()
+
+	if len(args) == 0 {
+		args = []string{"up"}
+	}
+
+	switch args[0] {
+	case "up":
+
+		if err := migrate.Up(sdb); err != nil {
+			panic(err)
+		}
+
+	case "down":
+
+		n := 1
+		if len(args) > 1 {
+			if parsed, err := strconv.Atoi(args[1]); err == nil {
+				n = parsed
+			}
+		}
+
+		if err := migrate.Rollback(sdb, n); err != nil {
+			panic(err)
+		}
+
+	case "status":
+
+		statuses, err := migrate.GetStatus(sdb)
+		if err != nil {
+			panic(err)
+		}
+
+		for _, s := range statuses {
+			fmt.Printf("%s\t%s\tapplicata=%t\n", s.ID, s.Description, s.Applied)
+		}
+
+	default:
+		fmt.Println("sottocomandi disponibili: up, down, status")
+	}
+}
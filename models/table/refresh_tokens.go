@@ -0,0 +1,94 @@
+package table
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+	record "github.com/IacopoMelani/Go-Starter-Project/pkg/models/table_record"
+)
+
+// RefreshToken - Rappresenta un refresh token opaco associato ad un utente, usato per rinnovare il JWT di accesso
+type RefreshToken struct {
+	tr record.TableRecord
+
+	ID        int64     `db:"id"`
+	TokenHash string    `db:"token_hash"`
+	UserID    int64     `db:"user_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+	Revoked   bool      `db:"revoked"`
+}
+
+// NewRefreshToken - Restituisce una nuova istanza di RefreshToken pronta per essere salvata
+func NewRefreshToken() *RefreshToken {
+
+	rt := new(RefreshToken)
+	rt.tr = *record.NewTableRecord(true, false)
+	rt.tr.SetSQLConnection(db.GetConnection())
+
+	return rt
+}
+
+// GetTableRecord - Implementa record.TableRecordInterface
+func (rt *RefreshToken) GetTableRecord() *record.TableRecord {
+	return &rt.tr
+}
+
+// GetPrimaryKeyName - Implementa record.TableRecordInterface
+func (rt *RefreshToken) GetPrimaryKeyName() string {
+	return "id"
+}
+
+// GetPrimaryKeyValue - Implementa record.TableRecordInterface
+func (rt *RefreshToken) GetPrimaryKeyValue() int64 {
+	return rt.ID
+}
+
+// GetTableName - Implementa record.TableRecordInterface
+func (rt *RefreshToken) GetTableName() string {
+	return "refresh_tokens"
+}
+
+// GetTableDDL - Implementa record.TableRecordInterface, usata dalla migrazione iniziale per creare la tabella
+func (rt *RefreshToken) GetTableDDL() string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS refresh_tokens (
+		id %s,
+		token_hash VARCHAR(255) NOT NULL,
+		user_id INTEGER NOT NULL,
+		expires_at %s NOT NULL,
+		revoked %s NOT NULL DEFAULT 0
+	)`, db.PrimaryKeyDDL(), db.DateTimeDDL(), db.BooleanDDL())
+}
+
+// IsExpired - Restituisce se il refresh token è scaduto rispetto all'istante passato
+func (rt *RefreshToken) IsExpired(now time.Time) bool {
+	return now.After(rt.ExpiresAt)
+}
+
+func init() {
+	db.RegisterTable("refresh_tokens", NewRefreshToken().GetTableDDL())
+}
+
+// LoadRefreshTokenByHash - Cerca un refresh token a partire dal suo hash, restituisce nil se non trovato
+func LoadRefreshTokenByHash(tokenHash string) (*RefreshToken, error) {
+
+	rt := NewRefreshToken()
+
+	query := db.Rebind("SELECT " + record.AllField(rt) + " FROM refresh_tokens WHERE token_hash = ?")
+
+	rows, err := db.GetConnection().Queryx(query, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	if err := record.LoadFromRow(rows, rt); err != nil {
+		return nil, err
+	}
+
+	return rt, nil
+}
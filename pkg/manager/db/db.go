@@ -0,0 +1,193 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SQLConnector - interfaccia che astrae le operazioni sul database utilizzate da TableRecord
+type SQLConnector interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Queryx(query string, args ...interface{}) (*sqlx.Rows, error)
+	QueryRowx(query string, args ...interface{}) *sqlx.Row
+	Prepare(query string) (*sql.Stmt, error)
+	Preparex(query string) (*sqlx.Stmt, error)
+}
+
+// Dialect - Rappresenta il dialetto SQL del driver configurato
+type Dialect string
+
+// Dialetti supportati
+const (
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+	DialectMSSQL    Dialect = "mssql"
+)
+
+// driverNames - Mappa ogni Dialect al nome del driver sqlx/database-sql registrato per quel dialetto
+var driverNames = map[Dialect]string{
+	DialectMySQL:    "mysql",
+	DialectPostgres: "postgres",
+	DialectSQLite:   "sqlite3",
+	DialectMSSQL:    "sqlserver",
+}
+
+var (
+	conn           *sqlx.DB
+	currentDialect Dialect
+)
+
+// Init - Si occupa di aprire la connessione al database per il Dialect passato, scegliendo il driver sqlx corretto
+func Init(dialect Dialect, dataSourceName string) error {
+
+	driverName, ok := driverNames[dialect]
+	if !ok {
+		return fmt.Errorf("dialetto non supportato: %s", dialect)
+	}
+
+	c, err := sqlx.Connect(driverName, dataSourceName)
+	if err != nil {
+		return err
+	}
+
+	conn = c
+	currentDialect = dialect
+
+	return nil
+}
+
+// GetDialect - Restituisce il Dialect della connessione attiva
+func GetDialect() Dialect {
+	return currentDialect
+}
+
+// SetDialect - Imposta il Dialect attivo senza aprire una connessione, utile ai test che generano query per dialetti diversi
+func SetDialect(d Dialect) {
+	currentDialect = d
+}
+
+// UsesReturningClause - Restituisce true se il Dialect recupera la chiave primaria tramite RETURNING/OUTPUT
+// invece che tramite LastInsertId
+func UsesReturningClause(d Dialect) bool {
+	return d == DialectPostgres || d == DialectMSSQL
+}
+
+// PrimaryKeyDDL - Restituisce la definizione DDL di una chiave primaria autoincrementante per il Dialect attivo,
+// usata dai modelli per generare GetTableDDL in modo portabile
+func PrimaryKeyDDL() string {
+
+	switch currentDialect {
+	case DialectPostgres:
+		return "SERIAL PRIMARY KEY"
+	case DialectMSSQL:
+		return "INT IDENTITY(1,1) PRIMARY KEY"
+	case DialectSQLite:
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	default:
+		return "INTEGER PRIMARY KEY AUTO_INCREMENT"
+	}
+}
+
+// BooleanDDL - Restituisce il tipo DDL più vicino ad un booleano per il Dialect attivo
+func BooleanDDL() string {
+
+	switch currentDialect {
+	case DialectPostgres:
+		return "BOOLEAN"
+	default:
+		return "TINYINT"
+	}
+}
+
+// DateTimeDDL - Restituisce il tipo DDL per una data/ora per il Dialect attivo
+func DateTimeDDL() string {
+
+	switch currentDialect {
+	case DialectPostgres:
+		return "TIMESTAMP"
+	case DialectMSSQL:
+		return "DATETIME2"
+	default:
+		return "DATETIME"
+	}
+}
+
+// Quote - Restituisce l'identificatore (tabella/colonna) correttamente quotato per il Dialect attivo
+func Quote(identifier string) string {
+
+	switch currentDialect {
+	case DialectPostgres, DialectSQLite:
+		return `"` + identifier + `"`
+	case DialectMSSQL:
+		return "[" + identifier + "]"
+	default:
+		return "`" + identifier + "`"
+	}
+}
+
+// Rebind - Sostituisce i placeholder generici "?" di query con quelli corretti per il Dialect attivo
+// ("?" su MySQL/SQLite, "$1", "$2", ... su Postgres, "@p1", "@p2", ... su MSSQL)
+func Rebind(query string) string {
+
+	switch currentDialect {
+	case DialectPostgres:
+		return rebind(query, func(i int) string { return "$" + strconv.Itoa(i) })
+	case DialectMSSQL:
+		return rebind(query, func(i int) string { return "@p" + strconv.Itoa(i) })
+	default:
+		return query
+	}
+}
+
+// rebind - Sostituisce ogni occorrenza di "?" con il placeholder generato da nextPlaceholder, in ordine di apparizione
+func rebind(query string, nextPlaceholder func(i int) string) string {
+
+	var sb strings.Builder
+	i := 0
+
+	for _, r := range query {
+
+		if r != '?' {
+			sb.WriteRune(r)
+			continue
+		}
+
+		i++
+		sb.WriteString(nextPlaceholder(i))
+	}
+
+	return sb.String()
+}
+
+// GetConnection - Restituisce il SQLConnector attivo
+func GetConnection() SQLConnector {
+	return conn
+}
+
+// GetDB - Restituisce la connessione *sqlx.DB attiva, utile a chi necessita di transazioni o funzioni non esposte da SQLConnector
+func GetDB() *sqlx.DB {
+	return conn
+}
+
+// TableDefinition - Rappresenta la definizione di una tabella che un modello contribuisce alla migrazione iniziale
+type TableDefinition struct {
+	Name string
+	DDL  string
+}
+
+var tables []TableDefinition
+
+// RegisterTable - Aggiunge la definizione CREATE TABLE di un modello al registro usato dalla migrazione iniziale
+func RegisterTable(name string, ddl string) {
+	tables = append(tables, TableDefinition{Name: name, DDL: ddl})
+}
+
+// GetTables - Restituisce tutte le definizioni di tabella registrate dai modelli
+func GetTables() []TableDefinition {
+	return tables
+}
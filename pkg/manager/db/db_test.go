@@ -0,0 +1,75 @@
+package db
+
+import "testing"
+
+// withDialect - Imposta temporaneamente il Dialect attivo per la durata di fn, ripristinando quello precedente al termine
+func withDialect(d Dialect, fn func()) {
+
+	previous := currentDialect
+	currentDialect = d
+	defer func() { currentDialect = previous }()
+
+	fn()
+}
+
+func TestQuote(t *testing.T) {
+
+	cases := []struct {
+		dialect  Dialect
+		expected string
+	}{
+		{DialectMySQL, "`id`"},
+		{DialectSQLite, `"id"`},
+		{DialectPostgres, `"id"`},
+		{DialectMSSQL, "[id]"},
+	}
+
+	for _, c := range cases {
+		withDialect(c.dialect, func() {
+			if got := Quote("id"); got != c.expected {
+				t.Errorf("Quote(%q) con dialect %s = %q, atteso %q", "id", c.dialect, got, c.expected)
+			}
+		})
+	}
+}
+
+func TestRebind(t *testing.T) {
+
+	cases := []struct {
+		dialect  Dialect
+		expected string
+	}{
+		{DialectMySQL, "SELECT * FROM t WHERE a = ? AND b = ?"},
+		{DialectSQLite, "SELECT * FROM t WHERE a = ? AND b = ?"},
+		{DialectPostgres, "SELECT * FROM t WHERE a = $1 AND b = $2"},
+		{DialectMSSQL, "SELECT * FROM t WHERE a = @p1 AND b = @p2"},
+	}
+
+	for _, c := range cases {
+		withDialect(c.dialect, func() {
+			got := Rebind("SELECT * FROM t WHERE a = ? AND b = ?")
+			if got != c.expected {
+				t.Errorf("Rebind(...) con dialect %s = %q, atteso %q", c.dialect, got, c.expected)
+			}
+		})
+	}
+}
+
+func TestUsesReturningClause(t *testing.T) {
+
+	cases := []struct {
+		dialect  Dialect
+		expected bool
+	}{
+		{DialectMySQL, false},
+		{DialectSQLite, false},
+		{DialectPostgres, true},
+		{DialectMSSQL, true},
+	}
+
+	for _, c := range cases {
+		if got := UsesReturningClause(c.dialect); got != c.expected {
+			t.Errorf("UsesReturningClause(%s) = %v, atteso %v", c.dialect, got, c.expected)
+		}
+	}
+}
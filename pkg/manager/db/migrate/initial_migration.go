@@ -0,0 +1,44 @@
+package migrate
+
+import (
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// initialMigrationID - ID della migrazione che crea le tabelle di tutti i modelli registrati tramite db.RegisterTable
+const initialMigrationID = "20200101000000"
+
+func init() {
+	Register(Migration{
+		ID:          initialMigrationID,
+		Description: "crea le tabelle di tutti i modelli registrati",
+		Up:          runInitialUp,
+		Down:        runInitialDown,
+	})
+}
+
+// runInitialUp - Esegue la DDL di ogni tabella registrata dai modelli tramite TableRecordInterface::GetTableDDL
+func runInitialUp(tx *sqlx.Tx) error {
+
+	for _, t := range db.GetTables() {
+		if _, err := tx.Exec(t.DDL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// runInitialDown - Elimina le tabelle create dalla migrazione iniziale, in ordine inverso di creazione
+func runInitialDown(tx *sqlx.Tx) error {
+
+	tables := db.GetTables()
+
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err := tx.Exec("DROP TABLE IF EXISTS " + tables[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationsTableName - Nome della tabella utilizzata per tracciare le migrazioni applicate
+const migrationsTableName = "migrations"
+
+// Migration - Rappresenta una singola migrazione dello schema, identificata da un ID ordinabile (timestamp).
+// Up/Down ricevono la transazione in corso, in modo che la DDL/DML della migrazione e la relativa riga di
+// tracciamento vengano confermate o annullate insieme
+type Migration struct {
+	ID          string
+	Description string
+	Up          func(*sqlx.Tx) error
+	Down        func(*sqlx.Tx) error
+}
+
+var migrations []Migration
+
+// Register - Aggiunge una migrazione al registro delle migrazioni, da richiamare tipicamente in un init()
+func Register(m Migration) {
+	migrations = append(migrations, m)
+}
+
+// sortedMigrations - Restituisce le migrazioni registrate ordinate per ID
+func sortedMigrations() []Migration {
+
+	sorted := make([]Migration, len(migrations))
+	copy(sorted, migrations)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	return sorted
+}
+
+// ensureMigrationsTable - Crea la tabella di tracciamento delle migrazioni se non esiste
+func ensureMigrationsTable(db *sqlx.DB) error {
+
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS ` + migrationsTableName + ` (
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		description VARCHAR(255) NOT NULL,
+		applied_at DATETIME NOT NULL
+	)`)
+
+	return err
+}
+
+// appliedIDs - Restituisce l'insieme degli ID delle migrazioni già applicate
+func appliedIDs(db *sqlx.DB) (map[string]bool, error) {
+
+	applied := map[string]bool{}
+
+	rows, err := db.Queryx("SELECT id FROM " + migrationsTableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		applied[id] = true
+	}
+
+	return applied, nil
+}
+
+// Up - Esegue tutte le migrazioni pendenti, ciascuna all'interno di una propria transazione
+func Up(db *sqlx.DB) error {
+
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range sortedMigrations() {
+
+		if applied[m.ID] {
+			continue
+		}
+
+		if err := runInTx(db, func(tx *sqlx.Tx) error {
+
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+
+			_, err := tx.Exec("INSERT INTO "+migrationsTableName+" (id, description, applied_at) VALUES (?, ?, ?)", m.ID, m.Description, time.Now())
+
+			return err
+
+		}); err != nil {
+			return fmt.Errorf("migrazione %s fallita: %w", m.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback - Annulla le ultime n migrazioni applicate, in ordine inverso
+func Rollback(db *sqlx.DB, n int) error {
+
+	if err := ensureMigrationsTable(db); err != nil {
+		return err
+	}
+
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return err
+	}
+
+	sorted := sortedMigrations()
+
+	toRollback := []Migration{}
+	for i := len(sorted) - 1; i >= 0 && len(toRollback) < n; i-- {
+		if applied[sorted[i].ID] {
+			toRollback = append(toRollback, sorted[i])
+		}
+	}
+
+	for _, m := range toRollback {
+
+		if err := runInTx(db, func(tx *sqlx.Tx) error {
+
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+
+			_, err := tx.Exec("DELETE FROM "+migrationsTableName+" WHERE id = ?", m.ID)
+
+			return err
+
+		}); err != nil {
+			return fmt.Errorf("rollback della migrazione %s fallito: %w", m.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Status - Rappresenta lo stato di una singola migrazione
+type Status struct {
+	ID          string
+	Description string
+	Applied     bool
+}
+
+// GetStatus - Restituisce lo stato (applicata/pendente) di tutte le migrazioni registrate
+func GetStatus(db *sqlx.DB) ([]Status, error) {
+
+	if err := ensureMigrationsTable(db); err != nil {
+		return nil, err
+	}
+
+	applied, err := appliedIDs(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []Status
+
+	for _, m := range sortedMigrations() {
+		statuses = append(statuses, Status{
+			ID:          m.ID,
+			Description: m.Description,
+			Applied:     applied[m.ID],
+		})
+	}
+
+	return statuses, nil
+}
+
+// runInTx - Esegue fn all'interno di una transazione, eseguendo il commit in caso di successo e il rollback in caso di errore
+func runInTx(db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+
+	tx, err := db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
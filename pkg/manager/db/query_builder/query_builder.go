@@ -0,0 +1,66 @@
+package query_builder
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+)
+
+// Builder - Si occupa della costruzione dinamica delle query SELECT (WHERE, ORDER BY, LIMIT, ...)
+// adattando i placeholder al Dialect della connessione attiva
+type Builder struct {
+	Params []interface{}
+
+	wheres  []string
+	orderBy string
+	limit   string
+}
+
+// Where - Aggiunge una condizione alla clausola WHERE, i placeholder vanno espressi con "?"
+func (b *Builder) Where(condition string, params ...interface{}) *Builder {
+	b.wheres = append(b.wheres, condition)
+	b.Params = append(b.Params, params...)
+	return b
+}
+
+// OrderBy - Imposta la clausola ORDER BY
+func (b *Builder) OrderBy(orderBy string) *Builder {
+	b.orderBy = orderBy
+	return b
+}
+
+// Limit - Imposta la clausola LIMIT
+func (b *Builder) Limit(limit int) *Builder {
+	b.limit = strconv.Itoa(limit)
+	return b
+}
+
+// BuildQuery - Costruisce la query SELECT completa per la tabella passata, con i placeholder già adattati al Dialect attivo
+func (b *Builder) BuildQuery(tableName string) string {
+
+	query := "SELECT * FROM " + db.Quote(tableName)
+
+	if len(b.wheres) > 0 {
+		query += " WHERE " + strings.Join(b.wheres, " AND ")
+	}
+
+	if b.orderBy != "" {
+		query += " ORDER BY " + b.orderBy
+	}
+
+	if b.limit != "" {
+		query += " LIMIT " + b.limit
+	}
+
+	return db.Rebind(query)
+}
+
+// ResetStmt - Ripulisce lo stato del builder per un nuovo utilizzo
+func (b *Builder) ResetStmt() {
+	b.wheres = nil
+	b.Params = nil
+	b.orderBy = ""
+	b.limit = ""
+}
+
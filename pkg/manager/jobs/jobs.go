@@ -0,0 +1,126 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// ErrJobNotFound - Errore restituito da Trigger quando nessun job è registrato con l'id passato
+var ErrJobNotFound = errors.New("job non trovato")
+
+// Job - Funzione eseguita dallo scheduler ad ogni trigger
+type Job func(ctx context.Context) error
+
+// jobEntry - Rappresenta un job registrato e la relativa entry nello scheduler cron
+type jobEntry struct {
+	id      string
+	spec    string
+	job     Job
+	entryID cron.EntryID
+}
+
+var (
+	mu        sync.Mutex
+	scheduler = cron.New()
+	jobsByID  = map[string]*jobEntry{}
+)
+
+// Start - Avvia lo scheduler, da richiamare una sola volta all'avvio dell'applicazione
+func Start() {
+	scheduler.Start()
+}
+
+// Stop - Ferma lo scheduler, attendendo la terminazione dei job in esecuzione
+func Stop() {
+	<-scheduler.Stop().Done()
+}
+
+// Register - Registra un nuovo job con id univoco e spec in formato cron, restituisce un errore se l'id è già in uso
+func Register(id string, spec string, job Job) error {
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := jobsByID[id]; exists {
+		return fmt.Errorf("job con id %q già registrato", id)
+	}
+
+	entry := &jobEntry{id: id, spec: spec, job: job}
+
+	entryID, err := scheduler.AddFunc(spec, func() {
+		runJobWithLock(context.Background(), entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	entry.entryID = entryID
+	jobsByID[id] = entry
+
+	return nil
+}
+
+// Remove - Rimuove un job precedentemente registrato, se presente
+func Remove(id string) {
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	entry, ok := jobsByID[id]
+	if !ok {
+		return
+	}
+
+	scheduler.Remove(entry.entryID)
+	delete(jobsByID, id)
+}
+
+// JobInfo - Informazioni su un job registrato, incluse le sue prossime/ultime esecuzioni
+type JobInfo struct {
+	ID   string
+	Spec string
+	Next time.Time
+	Prev time.Time
+}
+
+// List - Restituisce la lista dei job registrati con i relativi orari di esecuzione
+func List() []JobInfo {
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	infos := make([]JobInfo, 0, len(jobsByID))
+
+	for _, entry := range jobsByID {
+
+		e := scheduler.Entry(entry.entryID)
+
+		infos = append(infos, JobInfo{
+			ID:   entry.id,
+			Spec: entry.spec,
+			Next: e.Next,
+			Prev: e.Prev,
+		})
+	}
+
+	return infos
+}
+
+// Trigger - Esegue immediatamente il job con l'id passato, rispettando il lock distribuito tra istanze
+func Trigger(ctx context.Context, id string) error {
+
+	mu.Lock()
+	entry, ok := jobsByID[id]
+	mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrJobNotFound, id)
+	}
+
+	return runJobWithLock(ctx, entry)
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRegisterRejectsDuplicateID(t *testing.T) {
+
+	t.Cleanup(func() { Remove("duplicate-job") })
+
+	if err := Register("duplicate-job", "@every 1h", func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("prima registrazione fallita inaspettatamente: %s", err)
+	}
+
+	if err := Register("duplicate-job", "@every 1h", func(ctx context.Context) error { return nil }); err == nil {
+		t.Error("attesa di un errore registrando due job con lo stesso id, nessun errore restituito")
+	}
+}
+
+func TestListIncludesRegisteredJobs(t *testing.T) {
+
+	t.Cleanup(func() { Remove("listed-job") })
+
+	if err := Register("listed-job", "@every 1h", func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("registrazione fallita inaspettatamente: %s", err)
+	}
+
+	found := false
+
+	for _, info := range List() {
+		if info.ID == "listed-job" {
+			found = true
+		}
+	}
+
+	if !found {
+		t.Error("il job registrato non compare nella lista restituita da List")
+	}
+}
+
+func TestTriggerUnknownJobReturnsErrJobNotFound(t *testing.T) {
+
+	err := Trigger(context.Background(), "does-not-exist")
+
+	if !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("atteso ErrJobNotFound per un job non registrato, ottenuto: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+)
+
+// ensureScheduledJobsTable - Crea la tabella scheduled_jobs se non esiste, usata per il lock a livello di riga
+// tra le diverse istanze dell'applicazione
+func ensureScheduledJobsTable() error {
+
+	ddl := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS scheduled_jobs (
+		id VARCHAR(255) NOT NULL PRIMARY KEY,
+		running %s NOT NULL DEFAULT 0,
+		locked_at %s NULL,
+		last_run_at %s NULL
+	)`, db.BooleanDDL(), db.DateTimeDDL(), db.DateTimeDDL())
+
+	_, err := db.GetDB().Exec(ddl)
+
+	return err
+}
+
+// acquireLock - Tenta di acquisire il lock a livello di riga per il job passato, restituisce true se acquisito
+func acquireLock(id string) (bool, error) {
+
+	conn := db.GetDB()
+
+	insertQuery := db.Rebind("INSERT INTO scheduled_jobs (id, running) SELECT ?, 0 WHERE NOT EXISTS (SELECT 1 FROM scheduled_jobs WHERE id = ?)")
+
+	if _, err := conn.Exec(insertQuery, id, id); err != nil {
+		return false, err
+	}
+
+	updateQuery := db.Rebind("UPDATE scheduled_jobs SET running = 1, locked_at = ? WHERE id = ? AND running = 0")
+
+	res, err := conn.Exec(updateQuery, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows == 1, nil
+}
+
+// releaseLock - Rilascia il lock a livello di riga acquisito per il job passato
+func releaseLock(id string) error {
+
+	query := db.Rebind("UPDATE scheduled_jobs SET running = 0, last_run_at = ? WHERE id = ?")
+
+	_, err := db.GetDB().Exec(query, time.Now(), id)
+
+	return err
+}
+
+// runJobWithLock - Acquisisce il lock distribuito del job ed esegue job.job se l'acquisizione ha successo;
+// se un'altra istanza ha già il lock il job viene semplicemente saltato
+func runJobWithLock(ctx context.Context, entry *jobEntry) error {
+
+	if err := ensureScheduledJobsTable(); err != nil {
+		return err
+	}
+
+	acquired, err := acquireLock(entry.id)
+	if err != nil {
+		return err
+	}
+
+	if !acquired {
+		return nil
+	}
+
+	defer releaseLock(entry.id)
+
+	return entry.job(ctx)
+}
@@ -0,0 +1,104 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ctxKey string
+
+// Chiavi usate per arricchire il context di logging
+const (
+	requestIDKey ctxKey = "request_id"
+	userIDKey    ctxKey = "user_id"
+	routeKey     ctxKey = "route"
+)
+
+// WithRequestID - Restituisce un context che porta con sé il request ID, usato dal middleware di routing
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// WithUserID - Restituisce un context che porta con sé l'user ID estratto dai claims del JWT
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey, id)
+}
+
+// WithRoute - Restituisce un context che porta con sé la route corrente
+func WithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey, route)
+}
+
+// ContextLogger - Logger che annota automaticamente ogni messaggio con i campi presenti nel context (request ID, user ID, route)
+type ContextLogger struct {
+	ctx context.Context
+}
+
+// WithContext - Restituisce un ContextLogger che arricchisce i messaggi con i campi presenti in ctx
+func WithContext(ctx context.Context) *ContextLogger {
+	return &ContextLogger{ctx: ctx}
+}
+
+// contextFields - Campi di logging estratti dal context, veicolati come argomento distinto del log record in modo
+// che i backend strutturati (es. quello JSON) possano leggerli come campi propri invece che come testo già renderizzato
+type contextFields struct {
+	requestID string
+	userID    string
+	route     string
+}
+
+// String - Implementa fmt.Stringer così che i backend testuali continuino a vedere il prefisso "key=value" di sempre
+func (f contextFields) String() string {
+
+	var parts []string
+
+	if f.requestID != "" {
+		parts = append(parts, "request_id="+f.requestID)
+	}
+
+	if f.userID != "" {
+		parts = append(parts, "user_id="+f.userID)
+	}
+
+	if f.route != "" {
+		parts = append(parts, "route="+f.route)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// fieldsFromContext - Estrae i campi di logging presenti nel context
+func fieldsFromContext(ctx context.Context) contextFields {
+
+	var f contextFields
+
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		f.requestID = id
+	}
+
+	if id, ok := ctx.Value(userIDKey).(string); ok {
+		f.userID = id
+	}
+
+	if route, ok := ctx.Value(routeKey).(string); ok {
+		f.route = route
+	}
+
+	return f
+}
+
+// Debugf - Logga a livello debug annotando il messaggio con i campi del context
+func (c *ContextLogger) Debugf(format string, args ...interface{}) {
+	logger.Debug(fieldsFromContext(c.ctx), fmt.Sprintf(format, args...))
+}
+
+// Infof - Logga a livello info annotando il messaggio con i campi del context
+func (c *ContextLogger) Infof(format string, args ...interface{}) {
+	logger.Info(fieldsFromContext(c.ctx), fmt.Sprintf(format, args...))
+}
+
+// Errorf - Logga a livello error annotando il messaggio con i campi del context
+func (c *ContextLogger) Errorf(format string, args ...interface{}) {
+	logger.Error(fieldsFromContext(c.ctx), fmt.Sprintf(format, args...))
+}
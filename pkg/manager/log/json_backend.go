@@ -0,0 +1,68 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/op/go-logging"
+)
+
+// jsonBackend - Implementa logging.Backend scrivendo ogni record come riga JSON, pensato per l'ingestione in ELK/Loki
+type jsonBackend struct {
+	out io.Writer
+}
+
+// jsonRecord - Rappresentazione serializzata di un singolo record di log, con request ID/user ID/route come campi
+// propri (non testo già renderizzato) così da essere filtrabili in ELK/Loki
+type jsonRecord struct {
+	Time      string `json:"time"`
+	Level     string `json:"level"`
+	Module    string `json:"module"`
+	RequestID string `json:"request_id,omitempty"`
+	UserID    string `json:"user_id,omitempty"`
+	Route     string `json:"route,omitempty"`
+	Message   string `json:"message"`
+}
+
+// newJSONBackend - Restituisce un logging.Backend che scrive su out in formato JSON
+func newJSONBackend(out io.Writer) logging.Backend {
+	return &jsonBackend{out: out}
+}
+
+// Log - Implementa logging.Backend
+func (b *jsonBackend) Log(level logging.Level, calldepth int, rec *logging.Record) error {
+
+	entry := jsonRecord{
+		Time:   rec.Time.Format(time.RFC3339),
+		Level:  level.String(),
+		Module: rec.Module,
+	}
+
+	var messageParts []string
+
+	for _, arg := range rec.Args {
+
+		if f, ok := arg.(contextFields); ok {
+			entry.RequestID = f.requestID
+			entry.UserID = f.userID
+			entry.Route = f.route
+			continue
+		}
+
+		messageParts = append(messageParts, fmt.Sprint(arg))
+	}
+
+	entry.Message = strings.Join(messageParts, " ")
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.out.Write(append(data, '\n'))
+
+	return err
+}
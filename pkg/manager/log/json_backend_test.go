@@ -0,0 +1,52 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/op/go-logging"
+)
+
+func TestJSONBackendLogEmitsStructuredFields(t *testing.T) {
+
+	var buf bytes.Buffer
+
+	backend := newJSONBackend(&buf)
+
+	rec := &logging.Record{
+		Time:   time.Now(),
+		Module: "test",
+		Level:  logging.DEBUG,
+		Args: []interface{}{
+			contextFields{requestID: "req-1", userID: "42", route: "/restricted/jobs"},
+			"query=\"SELECT 1\" params=[]",
+		},
+	}
+
+	if err := backend.Log(logging.DEBUG, 0, rec); err != nil {
+		t.Fatalf("Log ha restituito un errore inatteso: %s", err)
+	}
+
+	var entry jsonRecord
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("output non è JSON valido: %s", err)
+	}
+
+	if entry.RequestID != "req-1" {
+		t.Errorf("RequestID = %q, atteso %q", entry.RequestID, "req-1")
+	}
+
+	if entry.UserID != "42" {
+		t.Errorf("UserID = %q, atteso %q", entry.UserID, "42")
+	}
+
+	if entry.Route != "/restricted/jobs" {
+		t.Errorf("Route = %q, atteso %q", entry.Route, "/restricted/jobs")
+	}
+
+	if entry.Message != `query="SELECT 1" params=[]` {
+		t.Errorf("Message = %q, atteso %q", entry.Message, `query="SELECT 1" params=[]`)
+	}
+}
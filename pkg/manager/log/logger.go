@@ -2,8 +2,14 @@ package log
 
 import (
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/IacopoMelani/Go-Starter-Project/config"
 
 	"github.com/op/go-logging"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Variabili relative ai formati default di log
@@ -56,3 +62,60 @@ func NewLogBackend(out io.Writer, prefix string, flag int, level logging.Level,
 
 	backendList = append(backendList, backendLevel)
 }
+
+// NewJSONLogBackend - Aggiunge un backend che scrive ogni record come riga JSON, pensato per l'ingestione in ELK/Loki
+func NewJSONLogBackend(out io.Writer, level logging.Level) {
+
+	backendLevel := logging.AddModuleLevel(newJSONBackend(out))
+	backendLevel.SetLevel(level, "")
+
+	backendList = append(backendList, backendLevel)
+}
+
+// NewRotatingFileLogBackend - Aggiunge un backend che scrive su file applicando una rotazione basata su dimensione e numero di backup
+func NewRotatingFileLogBackend(logDir string, maxSizeMB int, maxBackups int, level logging.Level, asJSON bool) {
+
+	writer := &lumberjack.Logger{
+		Filename:   filepath.Join(logDir, "app.log"),
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+	}
+
+	if asJSON {
+		NewJSONLogBackend(writer, level)
+		return
+	}
+
+	NewLogBackend(writer, "", 0, level, DefaultLogFormatter)
+}
+
+// ParseLevel - Converte una stringa di livello (DEBUG, INFO, WARNING, ERROR, ...) nel logging.Level corrispondente, usando INFO come default
+func ParseLevel(level string) logging.Level {
+
+	l, err := logging.LogLevel(level)
+	if err != nil {
+		return logging.INFO
+	}
+
+	return l
+}
+
+// InitFromConfig - Configura i backend di logging (stdout testuale o JSON, file con rotazione) a partire da
+// config.CacheConfig e inizializza il logger
+func InitFromConfig(appName string, conf *config.CacheConfig) {
+
+	level := ParseLevel(conf.LogLevel)
+	isJSON := strings.ToLower(conf.LogFormat) == "json"
+
+	if isJSON {
+		NewJSONLogBackend(os.Stdout, level)
+	} else {
+		NewLogBackend(os.Stdout, "", 0, level, DefaultLogFormatter)
+	}
+
+	if conf.LogDir != "" {
+		NewRotatingFileLogBackend(conf.LogDir, conf.LogMaxSizeMB, conf.LogMaxBackups, level, isJSON)
+	}
+
+	Init(appName)
+}
@@ -0,0 +1,128 @@
+package record
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+)
+
+// GetFieldMapper - Restituisce, nell'ordine di definizione della struct, i nomi delle colonne mappate tramite tag
+// `db` di ti e i rispettivi reflect.Value, usati per generare le query di insert/update e per leggerne i valori
+func GetFieldMapper(ti TableRecordInterface) ([]string, []reflect.Value) {
+
+	v := reflect.ValueOf(ti)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	t := v.Type()
+
+	var names []string
+	var values []reflect.Value
+
+	for i := 0; i < t.NumField(); i++ {
+
+		tag := t.Field(i).Tag.Get("db")
+		if tag == "" {
+			continue
+		}
+
+		names = append(names, tag)
+		values = append(values, v.Field(i))
+	}
+
+	return names, values
+}
+
+// getFieldsValueNoPrimary - Restituisce i valori dei campi mappati di ti escludendo la chiave primaria, nello stesso
+// ordine di colonne usato da genSaveQuery/genUpdateQuery per generare i relativi placeholder
+func getFieldsValueNoPrimary(ti TableRecordInterface) []interface{} {
+
+	names, values := GetFieldMapper(ti)
+
+	fValues := make([]interface{}, 0, len(names))
+
+	for i, name := range names {
+
+		if name == ti.GetPrimaryKeyName() {
+			continue
+		}
+
+		fValues = append(fValues, values[i].Interface())
+	}
+
+	return fValues
+}
+
+// genSaveQuery - Genera la query di inserimento per ti con identificatori quotati e placeholder per il Dialect
+// attivo; su Postgres/MSSQL include la clausola RETURNING/OUTPUT necessaria a recuperare la chiave primaria generata
+func genSaveQuery(ti TableRecordInterface) string {
+
+	names, _ := GetFieldMapper(ti)
+
+	var columns []string
+	var placeholders []string
+
+	for _, name := range names {
+
+		if name == ti.GetPrimaryKeyName() {
+			continue
+		}
+
+		columns = append(columns, db.Quote(name))
+		placeholders = append(placeholders, "?")
+	}
+
+	query := "INSERT INTO " + db.Quote(ti.GetTableName()) + " (" + strings.Join(columns, ",") + ")"
+
+	if db.GetDialect() == db.DialectMSSQL {
+		query += " OUTPUT INSERTED." + db.Quote(ti.GetPrimaryKeyName())
+	}
+
+	query += " VALUES (" + strings.Join(placeholders, ",") + ")"
+
+	if db.GetDialect() == db.DialectPostgres {
+		query += " RETURNING " + db.Quote(ti.GetPrimaryKeyName())
+	}
+
+	return db.Rebind(query)
+}
+
+// genUpdateQuery - Genera la query di aggiornamento per ti con identificatori quotati e placeholder per il Dialect
+// attivo; su Postgres/MSSQL include la clausola RETURNING/OUTPUT richiesta da executeSaveUpdateQuery per rileggere
+// la chiave primaria dopo l'update
+func genUpdateQuery(ti TableRecordInterface) string {
+
+	names, _ := GetFieldMapper(ti)
+
+	var sets []string
+
+	for _, name := range names {
+
+		if name == ti.GetPrimaryKeyName() {
+			continue
+		}
+
+		sets = append(sets, db.Quote(name)+" = ?")
+	}
+
+	query := "UPDATE " + db.Quote(ti.GetTableName()) + " SET " + strings.Join(sets, ",")
+
+	if db.GetDialect() == db.DialectMSSQL {
+		query += " OUTPUT INSERTED." + db.Quote(ti.GetPrimaryKeyName())
+	}
+
+	query += " WHERE " + db.Quote(ti.GetPrimaryKeyName()) + " = ?"
+
+	if db.GetDialect() == db.DialectPostgres {
+		query += " RETURNING " + db.Quote(ti.GetPrimaryKeyName())
+	}
+
+	return db.Rebind(query)
+}
+
+// genDeleteQuery - Genera la query di cancellazione per ti con identificatori quotati e placeholder per il Dialect attivo
+func genDeleteQuery(ti TableRecordInterface) string {
+	return db.Rebind("DELETE FROM " + db.Quote(ti.GetTableName()) + " WHERE " + db.Quote(ti.GetPrimaryKeyName()) + " = ?")
+}
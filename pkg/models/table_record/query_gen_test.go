@@ -0,0 +1,80 @@
+package record
+
+import (
+	"testing"
+
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
+)
+
+// fakeRecord - Implementazione minimale di TableRecordInterface usata per testare la generazione delle query
+type fakeRecord struct {
+	tr TableRecord
+
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+func (f *fakeRecord) GetTableRecord() *TableRecord { return &f.tr }
+func (f *fakeRecord) GetPrimaryKeyName() string    { return "id" }
+func (f *fakeRecord) GetPrimaryKeyValue() int64    { return f.ID }
+func (f *fakeRecord) GetTableName() string         { return "fakes" }
+func (f *fakeRecord) GetTableDDL() string          { return "" }
+
+func TestGenSaveQueryPerDialect(t *testing.T) {
+
+	ti := &fakeRecord{}
+
+	cases := map[db.Dialect]string{
+		db.DialectMySQL:    "INSERT INTO `fakes` (`name`) VALUES (?)",
+		db.DialectSQLite:   `INSERT INTO "fakes" ("name") VALUES (?)`,
+		db.DialectPostgres: `INSERT INTO "fakes" ("name") VALUES ($1) RETURNING "id"`,
+		db.DialectMSSQL:    "INSERT INTO [fakes] ([name]) OUTPUT INSERTED.[id] VALUES (@p1)",
+	}
+
+	for dialect, expected := range cases {
+		db.SetDialect(dialect)
+
+		if got := genSaveQuery(ti); got != expected {
+			t.Errorf("genSaveQuery con dialect %s = %q, atteso %q", dialect, got, expected)
+		}
+	}
+}
+
+func TestGenUpdateQueryPerDialect(t *testing.T) {
+
+	ti := &fakeRecord{}
+
+	cases := map[db.Dialect]string{
+		db.DialectMySQL:    "UPDATE `fakes` SET `name` = ? WHERE `id` = ?",
+		db.DialectSQLite:   `UPDATE "fakes" SET "name" = ? WHERE "id" = ?`,
+		db.DialectPostgres: `UPDATE "fakes" SET "name" = $1 WHERE "id" = $2 RETURNING "id"`,
+		db.DialectMSSQL:    "UPDATE [fakes] SET [name] = @p1 OUTPUT INSERTED.[id] WHERE [id] = @p2",
+	}
+
+	for dialect, expected := range cases {
+		db.SetDialect(dialect)
+
+		if got := genUpdateQuery(ti); got != expected {
+			t.Errorf("genUpdateQuery con dialect %s = %q, atteso %q", dialect, got, expected)
+		}
+	}
+}
+
+func TestGenDeleteQueryPerDialect(t *testing.T) {
+
+	ti := &fakeRecord{}
+
+	cases := map[db.Dialect]string{
+		db.DialectMySQL:    "DELETE FROM `fakes` WHERE `id` = ?",
+		db.DialectPostgres: `DELETE FROM "fakes" WHERE "id" = $1`,
+		db.DialectMSSQL:    "DELETE FROM [fakes] WHERE [id] = @p1",
+	}
+
+	for dialect, expected := range cases {
+		db.SetDialect(dialect)
+
+		if got := genDeleteQuery(ti); got != expected {
+			t.Errorf("genDeleteQuery con dialect %s = %q, atteso %q", dialect, got, expected)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 package record
 
 import (
+	"context"
 	"errors"
 	"strings"
 
 	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db"
 	builder "github.com/IacopoMelani/Go-Starter-Project/pkg/manager/db/query_builder"
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/log"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -18,6 +20,37 @@ type TableRecordInterface interface {
 	GetPrimaryKeyName() string
 	GetPrimaryKeyValue() int64
 	GetTableName() string
+	GetTableDDL() string
+}
+
+// BeforeSaver - interfaccia opzionale invocata prima dell'inserimento di un nuovo record
+type BeforeSaver interface {
+	BeforeSave() error
+}
+
+// AfterSaver - interfaccia opzionale invocata dopo l'inserimento di un nuovo record
+type AfterSaver interface {
+	AfterSave() error
+}
+
+// BeforeUpdater - interfaccia opzionale invocata prima dell'aggiornamento di un record
+type BeforeUpdater interface {
+	BeforeUpdate() error
+}
+
+// AfterUpdater - interfaccia opzionale invocata dopo l'aggiornamento di un record
+type AfterUpdater interface {
+	AfterUpdate() error
+}
+
+// BeforeDeleter - interfaccia opzionale invocata prima della cancellazione di un record
+type BeforeDeleter interface {
+	BeforeDelete() error
+}
+
+// AfterDeleter - interfaccia opzionale invocata dopo la cancellazione di un record
+type AfterDeleter interface {
+	AfterDelete() error
 }
 
 // TableRecord - Struct per l'implementazione di TableRecordInterface
@@ -34,62 +67,110 @@ func getTableRecordConnection(ti TableRecordInterface) db.SQLConnector {
 	return ti.GetTableRecord().db
 }
 
-// save - Si occupa di inserire un nuovo record nella tabella
-func save(ti TableRecordInterface) error {
+// withImplicitTransaction - Se la connessione sottostante supporta le transazioni la apre ed esegue fn al suo interno
+// occupandosi di commit/rollback, altrimenti esegue fn direttamente sulla connessione esistente
+func withImplicitTransaction(conn db.SQLConnector, fn func(db.SQLConnector) error) error {
+
+	sdb, ok := conn.(*sqlx.DB)
+	if !ok {
+		return fn(conn)
+	}
+
+	return WithTransaction(sdb, func(tx *Tx) error {
+		return fn(tx)
+	})
+}
+
+// save - Si occupa di inserire un nuovo record nella tabella usando la connessione passata
+func save(ctx context.Context, ti TableRecordInterface, conn db.SQLConnector) error {
 
 	t := ti.GetTableRecord()
 
+	if bs, ok := ti.(BeforeSaver); ok {
+		if err := bs.BeforeSave(); err != nil {
+			return err
+		}
+	}
+
 	query := genSaveQuery(ti)
 	fValue := getFieldsValueNoPrimary(ti)
-	id, err := t.executeSaveUpdateQuery(query, fValue)
+
+	id, err := t.executeSaveUpdateQuery(ctx, conn, query, fValue)
 	if err != nil {
 		return err
 	}
 
-	if err := LoadByID(ti, id); err != nil {
+	if err := loadByID(ctx, ti, id, conn); err != nil {
 		return err
 	}
 
 	t.SetIsNew(false)
 
+	if as, ok := ti.(AfterSaver); ok {
+		if err := as.AfterSave(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// update - Si occupa di aggiornare il record nel database
-func update(ti TableRecordInterface) error {
+// update - Si occupa di aggiornare il record nel database usando la connessione passata
+func update(ctx context.Context, ti TableRecordInterface, conn db.SQLConnector) error {
 
 	t := ti.GetTableRecord()
 
+	if bu, ok := ti.(BeforeUpdater); ok {
+		if err := bu.BeforeUpdate(); err != nil {
+			return err
+		}
+	}
+
 	query := genUpdateQuery(ti)
 	fValue := getFieldsValueNoPrimary(ti)
-	_, err := t.executeSaveUpdateQuery(query, append(fValue, ti.GetPrimaryKeyValue()))
+
+	_, err := t.executeSaveUpdateQuery(ctx, conn, query, append(fValue, ti.GetPrimaryKeyValue()))
 	if err != nil {
 		return err
 	}
 
-	if err := LoadByID(ti, ti.GetPrimaryKeyValue()); err != nil {
+	if err := loadByID(ctx, ti, ti.GetPrimaryKeyValue(), conn); err != nil {
 		return err
 	}
 
+	if au, ok := ti.(AfterUpdater); ok {
+		if err := au.AfterUpdate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-// executeSaveUpdateQuery - Si occupa di eseguire fisicamente la query, in caso di successo restituisce l'Id appena inserito
-func (t *TableRecord) executeSaveUpdateQuery(query string, params []interface{}) (int64, error) {
+// executeSaveUpdateQuery - Si occupa di eseguire fisicamente la query sulla connessione passata, in caso di successo
+// restituisce l'Id appena inserito. Su Postgres/MSSQL la query deve già contenere la clausola RETURNING/OUTPUT e l'Id
+// viene letto dalla riga restituita, sugli altri dialetti viene usato LastInsertId
+func (t *TableRecord) executeSaveUpdateQuery(ctx context.Context, conn db.SQLConnector, query string, params []interface{}) (int64, error) {
 
-	db := t.db
+	log.WithContext(ctx).Debugf("query=%q params=%v", query, params)
 
-	res, err := db.Exec(query, params...)
-	if err != nil {
-		return 0, err
+	if db.UsesReturningClause(db.GetDialect()) {
+
+		var id int64
+
+		if err := conn.QueryRowx(query, params...).Scan(&id); err != nil {
+			return 0, err
+		}
+
+		return id, nil
 	}
 
-	lastID, err := res.LastInsertId()
+	res, err := conn.Exec(query, params...)
 	if err != nil {
 		return 0, err
 	}
 
-	return lastID, nil
+	return res.LastInsertId()
 }
 
 // AllField - Restitusice tutti i campi per la select *
@@ -132,12 +213,16 @@ func All(ntm NewTableModel) ([]TableRecordInterface, error) {
 	return result, nil
 }
 
-// Delete - Si occupa di cancellare un record sul database
-func Delete(ti TableRecordInterface) (int64, error) {
+// deleteWith - Si occupa di cancellare un record sul database usando la connessione passata
+func deleteWith(ctx context.Context, ti TableRecordInterface, conn db.SQLConnector) (int64, error) {
 
-	db := getTableRecordConnection(ti)
+	if bd, ok := ti.(BeforeDeleter); ok {
+		if err := bd.BeforeDelete(); err != nil {
+			return 0, err
+		}
+	}
 
-	stmt, err := db.Prepare(genDeleteQuery(ti))
+	stmt, err := conn.Prepare(genDeleteQuery(ti))
 	if err != nil {
 		return 0, err
 	}
@@ -153,11 +238,49 @@ func Delete(ti TableRecordInterface) (int64, error) {
 		return 0, nil
 	}
 
+	if ad, ok := ti.(AfterDeleter); ok {
+		if err := ad.AfterDelete(); err != nil {
+			return 0, err
+		}
+	}
+
 	return rows, nil
 }
 
+// Delete - Si occupa di cancellare un record sul database aprendo una transazione implicita
+func Delete(ti TableRecordInterface) (int64, error) {
+	return DeleteCtx(context.Background(), ti)
+}
+
+// DeleteCtx - Si occupa di cancellare un record sul database aprendo una transazione implicita, correlando i log
+// generati al ctx passato (es. request ID)
+func DeleteCtx(ctx context.Context, ti TableRecordInterface) (int64, error) {
+
+	conn := getTableRecordConnection(ti)
+
+	var rows int64
+
+	err := withImplicitTransaction(conn, func(txConn db.SQLConnector) error {
+		r, err := deleteWith(ctx, ti, txConn)
+		rows = r
+		return err
+	})
+
+	return rows, err
+}
+
+// DeleteTx - Si occupa di cancellare un record sul database all'interno della transazione passata
+func DeleteTx(tx *Tx, ti TableRecordInterface) (int64, error) {
+	return deleteWith(context.Background(), ti, tx)
+}
+
 // ExecQuery - Esegue la query costruita con QueryBuilder
 func ExecQuery(ti TableRecordInterface, ntm NewTableModel) ([]TableRecordInterface, error) {
+	return ExecQueryCtx(context.Background(), ti, ntm)
+}
+
+// ExecQueryCtx - Esegue la query costruita con QueryBuilder, correlando il log della query al ctx passato (es. request ID)
+func ExecQueryCtx(ctx context.Context, ti TableRecordInterface, ntm NewTableModel) ([]TableRecordInterface, error) {
 
 	t := ti.GetTableRecord()
 
@@ -167,6 +290,8 @@ func ExecQuery(ti TableRecordInterface, ntm NewTableModel) ([]TableRecordInterfa
 	}
 	defer stmt.Close()
 
+	log.WithContext(ctx).Debugf("query=%q params=%v", t.BuildQuery(ti.GetTableName()), t.Params)
+
 	rows, err := stmt.Queryx(t.Params...)
 	if err != nil {
 		return nil, err
@@ -191,16 +316,16 @@ func ExecQuery(ti TableRecordInterface, ntm NewTableModel) ([]TableRecordInterfa
 	return tiList, nil
 }
 
-// LoadByID - Carica l'istanza passata con i valori della sua tabella ricercando per chiave primaria
-func LoadByID(ti TableRecordInterface, id int64) error {
-
-	db := getTableRecordConnection(ti)
+// loadByID - Carica l'istanza passata con i valori della sua tabella ricercando per chiave primaria, usando la connessione passata
+func loadByID(ctx context.Context, ti TableRecordInterface, id int64, conn db.SQLConnector) error {
 
-	query := "SELECT " + AllField(ti) + " FROM " + ti.GetTableName() + " WHERE " + ti.GetPrimaryKeyName() + " = ?"
+	query := db.Rebind("SELECT " + AllField(ti) + " FROM " + ti.GetTableName() + " WHERE " + ti.GetPrimaryKeyName() + " = ?")
 
 	params := []interface{}{interface{}(id)}
 
-	stmt, err := db.Preparex(query)
+	log.WithContext(ctx).Debugf("query=%q params=%v", query, params)
+
+	stmt, err := conn.Preparex(query)
 	if err != nil {
 		return err
 	}
@@ -222,6 +347,22 @@ func LoadByID(ti TableRecordInterface, id int64) error {
 	return nil
 }
 
+// LoadByID - Carica l'istanza passata con i valori della sua tabella ricercando per chiave primaria
+func LoadByID(ti TableRecordInterface, id int64) error {
+	return loadByID(context.Background(), ti, id, getTableRecordConnection(ti))
+}
+
+// LoadByIDCtx - Carica l'istanza passata con i valori della sua tabella ricercando per chiave primaria, correlando
+// il log della query al ctx passato (es. request ID)
+func LoadByIDCtx(ctx context.Context, ti TableRecordInterface, id int64) error {
+	return loadByID(ctx, ti, id, getTableRecordConnection(ti))
+}
+
+// LoadByIDTx - Carica l'istanza passata con i valori della sua tabella ricercando per chiave primaria, all'interno della transazione passata
+func LoadByIDTx(tx *Tx, ti TableRecordInterface, id int64) error {
+	return loadByID(context.Background(), ti, id, tx)
+}
+
 // LoadFromRow - Si occupa di caricare la struct dal result - row della query
 func LoadFromRow(r *sqlx.Rows, tri TableRecordInterface) error {
 
@@ -244,8 +385,15 @@ func NewTableRecord(isNew bool, isReadOnly bool) *TableRecord {
 	return tr
 }
 
-// Save - Si occupa di eseguire il salvataggio della TableRecord eseguendo un inserimento se TableRecord::isNew risulta false, altrimenti ne aggiorna il valore
+// Save - Si occupa di eseguire il salvataggio della TableRecord (inserimento se nuova, aggiornamento altrimenti)
+// aprendo una transazione implicita sulla connessione del record
 func Save(ti TableRecordInterface) error {
+	return SaveCtx(context.Background(), ti)
+}
+
+// SaveCtx - Si occupa di eseguire il salvataggio della TableRecord aprendo una transazione implicita sulla
+// connessione del record, correlando le query generate al ctx passato (es. request ID, user ID)
+func SaveCtx(ctx context.Context, ti TableRecordInterface) error {
 
 	t := ti.GetTableRecord()
 
@@ -253,22 +401,32 @@ func Save(ti TableRecordInterface) error {
 		return errors.New("Read-only model")
 	}
 
-	if t.isNew {
+	conn := getTableRecordConnection(ti)
 
-		err := save(ti)
-		if err != nil {
-			return err
+	return withImplicitTransaction(conn, func(txConn db.SQLConnector) error {
+
+		if t.isNew {
+			return save(ctx, ti, txConn)
 		}
 
-	} else {
+		return update(ctx, ti, txConn)
+	})
+}
 
-		err := update(ti)
-		if err != nil {
-			return err
-		}
+// SaveTx - Si occupa di eseguire il salvataggio della TableRecord all'interno della transazione passata
+func SaveTx(tx *Tx, ti TableRecordInterface) error {
+
+	t := ti.GetTableRecord()
+
+	if t.isReadOnly {
+		return errors.New("Read-only model")
 	}
 
-	return nil
+	if t.isNew {
+		return save(context.Background(), ti, tx)
+	}
+
+	return update(context.Background(), ti, tx)
 }
 
 // GetDB - Restituisce la risorsa di connessione al database
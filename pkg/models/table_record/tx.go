@@ -0,0 +1,67 @@
+package record
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Tx - Wrapper su *sqlx.Tx che implementa db.SQLConnector, usato per eseguire Save/Delete/LoadByID
+// all'interno di una transazione esplicita
+type Tx struct {
+	tx *sqlx.Tx
+}
+
+// Exec - Implementa db.SQLConnector eseguendo la query sulla transazione
+func (t *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return t.tx.Exec(query, args...)
+}
+
+// Queryx - Implementa db.SQLConnector eseguendo la query sulla transazione
+func (t *Tx) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return t.tx.Queryx(query, args...)
+}
+
+// QueryRowx - Implementa db.SQLConnector eseguendo la query sulla transazione e restituendo una singola riga
+func (t *Tx) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	return t.tx.QueryRowx(query, args...)
+}
+
+// Prepare - Implementa db.SQLConnector preparando lo statement sulla transazione
+func (t *Tx) Prepare(query string) (*sql.Stmt, error) {
+	return t.tx.Prepare(query)
+}
+
+// Preparex - Implementa db.SQLConnector preparando lo statement sulla transazione
+func (t *Tx) Preparex(query string) (*sqlx.Stmt, error) {
+	return t.tx.Preparex(query)
+}
+
+// Commit - Conferma la transazione
+func (t *Tx) Commit() error {
+	return t.tx.Commit()
+}
+
+// Rollback - Annulla la transazione
+func (t *Tx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// WithTransaction - Apre una transazione sulla connessione passata ed esegue fn al suo interno,
+// eseguendo il commit in caso di successo e il rollback in caso di errore
+func WithTransaction(sdb *sqlx.DB, fn func(tx *Tx) error) error {
+
+	sqlxTx, err := sdb.Beginx()
+	if err != nil {
+		return err
+	}
+
+	tx := &Tx{tx: sqlxTx}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
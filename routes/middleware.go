@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/IacopoMelani/Go-Starter-Project/controllers"
+	"github.com/IacopoMelani/Go-Starter-Project/pkg/manager/log"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+	"github.com/labstack/echo"
+)
+
+// RequestLogger - Middleware che inietta un request ID nel context della richiesta e logga inizio/fine con la relativa latenza
+func RequestLogger() echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			requestID := uuid.New().String()
+
+			ctx := log.WithRequestID(c.Request().Context(), requestID)
+			ctx = log.WithRoute(ctx, c.Path())
+
+			c.SetRequest(c.Request().WithContext(ctx))
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+
+			start := time.Now()
+			log.WithContext(ctx).Infof("richiesta avviata: %s %s", c.Request().Method, c.Request().URL.Path)
+
+			err := next(c)
+
+			log.WithContext(c.Request().Context()).Infof("richiesta completata: %s %s in %s", c.Request().Method, c.Request().URL.Path, time.Since(start))
+
+			return err
+		}
+	}
+}
+
+// UserIDFromJWT - Middleware, da applicare dopo quello JWT, che estrae lo user ID dai claims e lo inietta nel context di logging
+func UserIDFromJWT() echo.MiddlewareFunc {
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+
+		return func(c echo.Context) error {
+
+			if token, ok := c.Get("user").(*jwt.Token); ok {
+				if claims, ok := token.Claims.(*controllers.JwtCustomClaims); ok {
+					ctx := log.WithUserID(c.Request().Context(), fmt.Sprintf("%d", claims.UserID))
+					c.SetRequest(c.Request().WithContext(ctx))
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -1,28 +1,46 @@
 package routes
 
 import (
+	"github.com/IacopoMelani/Go-Starter-Project/config"
 	"github.com/IacopoMelani/Go-Starter-Project/controllers"
 
 	"github.com/labstack/echo"
 	"github.com/labstack/echo/middleware"
 )
 
+// jwtConfig - Costruisce la configurazione del middleware JWT a partire dai valori in config.CacheConfig
+func jwtConfig() middleware.JWTConfig {
+
+	conf := config.GetInstance()
+
+	return middleware.JWTConfig{
+		Claims:     &controllers.JwtCustomClaims{},
+		SigningKey: []byte(conf.JWTSecret),
+		AuthScheme: "Bearer",
+	}
+}
+
 // InitGetRoutes - Dichiara tutte le route GET
 func InitGetRoutes(e *echo.Echo) {
 	e.GET("user/all", controllers.GetAllUser)
 	r := e.Group("/restricted")
 
-	// Configure middleware with the custom claims type
-	config := middleware.JWTConfig{
-		Claims:     &controllers.JwtCustomClaims{},
-		SigningKey: []byte("bomba"),
-	}
-	r.Use(middleware.JWTWithConfig(config))
+	r.Use(middleware.JWTWithConfig(jwtConfig()))
+	r.Use(UserIDFromJWT())
 	r.GET("/user/duration", controllers.GetDurataionUsers)
 	e.GET("user/duration", controllers.GetDurataionUsers)
+	r.GET("/jobs", controllers.ListJobs)
 }
 
 // InitPostRoutes - Dichiara tutte le route POST
 func InitPostRoutes(e *echo.Echo) {
 	e.POST("/user/login", controllers.Login)
+	e.POST("/user/refresh", controllers.RefreshToken)
+	e.POST("/user/logout", controllers.Logout)
+
+	r := e.Group("/restricted")
+
+	r.Use(middleware.JWTWithConfig(jwtConfig()))
+	r.Use(UserIDFromJWT())
+	r.POST("/jobs/:id/trigger", controllers.TriggerJob)
 }